@@ -0,0 +1,20 @@
+package main
+
+import "net/http"
+
+// preconditionFailedResponse is used when a client's If-Match header doesn't
+// match the record's current version, signalling that it needs to re-fetch
+// the resource before retrying its write.
+func (app *application) preconditionFailedResponse(w http.ResponseWriter, r *http.Request) {
+	message := "unable to update the record due to an edit conflict, please try again"
+	app.errorResponse(w, r, http.StatusPreconditionFailed, message)
+}
+
+// duplicateTitleResponse is used when an insert or update would violate the
+// movies table's uniqueness constraint on title. This is a conflict with
+// existing state, not a malformed request, so it gets its own 409 rather
+// than being bucketed with badRequestResponse.
+func (app *application) duplicateTitleResponse(w http.ResponseWriter, r *http.Request) {
+	message := "a movie with this title already exists"
+	app.errorResponse(w, r, http.StatusConflict, message)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"greenlight.alar.net/internal/validator"
+)
+
+// readString returns a string value from the query string, or the provided
+// default value if no matching key could be found.
+func (app *application) readString(qs url.Values, key string, defaultValue string) string {
+	s := qs.Get(key)
+
+	if s == "" {
+		return defaultValue
+	}
+
+	return s
+}
+
+// readCSV reads a string value from the query string and then splits it
+// into a slice on the comma character. If no matching key could be found, it
+// returns the provided default value.
+func (app *application) readCSV(qs url.Values, key string, defaultValue []string) []string {
+	csv := qs.Get(key)
+
+	if csv == "" {
+		return defaultValue
+	}
+
+	return strings.Split(csv, ",")
+}
+
+// readInt reads a string value from the query string and converts it to an
+// integer before returning. If no matching key could be found it returns the
+// provided default value. If the value couldn't be converted to an integer,
+// then we record an error message in the validator instance.
+func (app *application) readInt(qs url.Values, key string, defaultValue int, v *validator.Validator) int {
+	s := qs.Get(key)
+
+	if s == "" {
+		return defaultValue
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		v.AddError(key, "must be an integer value")
+		return defaultValue
+	}
+
+	return i
+}
+
+// etag wraps a movie's version token in the quoted form required by the
+// ETag/If-Match headers (RFC 7232).
+func etag(version string) string {
+	return fmt.Sprintf("%q", version)
+}
+
+var errIfMatchMismatch = errors.New("if-match mismatch")
+
+// ifMatchSatisfied reports whether the If-Match header value a client sent
+// matches the record's current version's ETag.
+func ifMatchSatisfied(ifMatch, currentVersion string) bool {
+	return ifMatch != "" && ifMatch == etag(currentVersion)
+}
+
+// requireIfMatch reads the If-Match header, which is mandatory for any
+// request that mutates a movie, and compares it against the record's
+// current version. It writes the appropriate error response itself and
+// returns a non-nil error if the request should not proceed.
+func (app *application) requireIfMatch(w http.ResponseWriter, r *http.Request, currentVersion string) error {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		err := errors.New("If-Match header is required")
+		app.badRequestResponse(w, r, err)
+		return err
+	}
+
+	if !ifMatchSatisfied(ifMatch, currentVersion) {
+		app.preconditionFailedResponse(w, r)
+		return errIfMatchMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestEtag(t *testing.T) {
+	got := etag("a1b2c3")
+	want := `"a1b2c3"`
+
+	if got != want {
+		t.Errorf("etag(%q) = %s, want %s", "a1b2c3", got, want)
+	}
+}
+
+func TestIfMatchSatisfied(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		version string
+		want    bool
+	}{
+		{name: "matching version", ifMatch: `"a1b2c3"`, version: "a1b2c3", want: true},
+		{name: "stale version", ifMatch: `"a1b2c3"`, version: "d4e5f6", want: false},
+		{name: "missing quotes", ifMatch: "a1b2c3", version: "a1b2c3", want: false},
+		{name: "empty header", ifMatch: "", version: "a1b2c3", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ifMatchSatisfied(tt.ifMatch, tt.version); got != tt.want {
+				t.Errorf("ifMatchSatisfied(%q, %q) = %v, want %v", tt.ifMatch, tt.version, got, tt.want)
+			}
+		})
+	}
+}
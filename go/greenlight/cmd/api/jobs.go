@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"greenlight.alar.net/internal/jobs"
+)
+
+// createJobHandler is an admin endpoint for enqueuing enrichment work
+// against a movie, e.g. {"type": "fetch_tmdb_metadata", "movie_id": 12}.
+func (app *application) createJobHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Type    jobs.Type `json:"type"`
+		MovieID int64     `json:"movie_id"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	switch input.Type {
+	case jobs.TypeFetchIMDBReviews, jobs.TypeFetchTMDBMetadata, jobs.TypeRefreshMovie:
+	default:
+		app.badRequestResponse(w, r, errors.New("unknown job type"))
+		return
+	}
+
+	job, err := app.models.Jobs.Enqueue(r.Context(), input.Type, struct {
+		MovieID int64 `json:"movie_id"`
+	}{MovieID: input.MovieID})
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"job": job}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// listJobsHandler returns every job in the queue, most recently created
+// first, for operators to inspect progress and failures.
+func (app *application) listJobsHandler(w http.ResponseWriter, r *http.Request) {
+	jobList, err := app.models.Jobs.GetAll(r.Context())
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"jobs": jobList}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	"greenlight.alar.net/internal/clients/imdb"
+	"greenlight.alar.net/internal/clients/tmdb"
+	"greenlight.alar.net/internal/jobs"
+)
+
+// jobPayload is the shape every job enqueued by createJobHandler carries.
+type jobPayload struct {
+	MovieID int64 `json:"movie_id"`
+}
+
+// startJobWorkers wires the enrichment handlers into a worker pool and runs
+// it until ctx is cancelled. main calls this once, after the database is
+// ready, alongside app.serve().
+func (app *application) startJobWorkers(ctx context.Context, tmdbAPIKey string, workers int) {
+	pool := jobs.NewPool(app.models.Jobs, app.logger)
+
+	tmdbClient := tmdb.NewClient(tmdbAPIKey)
+	imdbClient := imdb.NewClient()
+
+	pool.Register(jobs.TypeFetchTMDBMetadata, func(ctx context.Context, payload []byte) error {
+		var p jobPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+
+		return app.models.Movies.EnrichFromTMDB(ctx, p.MovieID, tmdbClient)
+	})
+
+	pool.Register(jobs.TypeFetchIMDBReviews, func(ctx context.Context, payload []byte) error {
+		var p jobPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+
+		return app.models.Movies.FetchReviews(ctx, p.MovieID, imdbClient)
+	})
+
+	pool.Register(jobs.TypeRefreshMovie, func(ctx context.Context, payload []byte) error {
+		var p jobPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+
+		if err := app.models.Movies.EnrichFromTMDB(ctx, p.MovieID, tmdbClient); err != nil {
+			return err
+		}
+
+		return app.models.Movies.FetchReviews(ctx, p.MovieID, imdbClient)
+	})
+
+	pool.Start(ctx, workers)
+}
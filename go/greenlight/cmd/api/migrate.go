@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"greenlight.alar.net/internal/db"
+)
+
+// runMigrateCommand handles `./api migrate <up|down|status|create <name>>`,
+// so operators don't need the goose CLI installed to manage the schema.
+func runMigrateCommand(cfg config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate <up|down|status|create> [args]")
+	}
+
+	if args[0] == "create" {
+		fs := flag.NewFlagSet("migrate create", flag.ExitOnError)
+		fs.Parse(args[1:])
+
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: migrate create <name>")
+		}
+
+		return db.MigrateCreate(fs.Arg(0))
+	}
+
+	database, err := db.Open(db.Config{DSN: cfg.db.dsn, MaxOpenConns: cfg.db.maxOpenConns, MaxIdleConns: cfg.db.maxIdleConns, MaxIdleTime: cfg.db.maxIdleTime})
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	switch args[0] {
+	case "up":
+		return db.MigrateUp(database)
+	case "down":
+		return db.MigrateDown(database)
+	case "status":
+		return db.MigrateStatus(database)
+	default:
+		return fmt.Errorf("unknown migrate subcommand %q", args[0])
+	}
+}
@@ -0,0 +1,69 @@
+// Package imdb scrapes the public IMDB reviews page for a title, since IMDB
+// doesn't offer a public reviews API.
+package imdb
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const reviewsURL = "https://www.imdb.com/title/%s/reviews"
+
+type Client struct {
+	HTTPClient *http.Client
+}
+
+func NewClient() *Client {
+	return &Client{
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type Review struct {
+	Author string
+	Body   string
+}
+
+// FetchReviews scrapes the first page of user reviews for the given IMDB
+// title ID (e.g. "tt0109830").
+func (c *Client) FetchReviews(imdbID string) ([]Review, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf(reviewsURL, imdbID), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; greenlightbot/1.0)")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("imdb: unexpected status %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviews []Review
+
+	doc.Find(".review-container").Each(func(_ int, s *goquery.Selection) {
+		author := strings.TrimSpace(s.Find(".display-name-link").Text())
+		body := strings.TrimSpace(s.Find(".text.show-more__control").Text())
+
+		if body == "" {
+			return
+		}
+
+		reviews = append(reviews, Review{Author: author, Body: body})
+	})
+
+	return reviews, nil
+}
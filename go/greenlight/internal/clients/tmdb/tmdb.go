@@ -0,0 +1,138 @@
+// Package tmdb is a minimal client for the subset of the TMDB API that the
+// enrichment jobs need: looking up a movie's metadata by title/year.
+package tmdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const baseURL = "https://api.themoviedb.org/3"
+
+type Client struct {
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+func NewClient(apiKey string) *Client {
+	return &Client{
+		APIKey:     apiKey,
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type Metadata struct {
+	TMDBID    string   `json:"tmdb_id"`
+	IMDBID    string   `json:"imdb_id"`
+	Summary   string   `json:"summary"`
+	Directors []string `json:"directors"`
+}
+
+type searchResponse struct {
+	Results []struct {
+		ID       int    `json:"id"`
+		Overview string `json:"overview"`
+	} `json:"results"`
+}
+
+// detailsResponse is the subset of the /movie/{id} response we care about.
+// Unlike the search endpoint, TMDB's details endpoint includes the linked
+// imdb_id directly.
+type detailsResponse struct {
+	IMDBID string `json:"imdb_id"`
+}
+
+type creditsResponse struct {
+	Crew []struct {
+		Name string `json:"name"`
+		Job  string `json:"job"`
+	} `json:"crew"`
+}
+
+// FindMovie looks up a movie by title and year and returns its TMDB
+// metadata, including the linked imdb_id and directors.
+func (c *Client) FindMovie(title string, year int32) (*Metadata, error) {
+	tmdbID, overview, err := c.search(title, year)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := c.details(tmdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	directors, err := c.directors(tmdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metadata{
+		TMDBID:    fmt.Sprintf("%d", tmdbID),
+		IMDBID:    details.IMDBID,
+		Summary:   overview,
+		Directors: directors,
+	}, nil
+}
+
+func (c *Client) search(title string, year int32) (tmdbID int, overview string, err error) {
+	endpoint := fmt.Sprintf("%s/search/movie?api_key=%s&query=%s&year=%d",
+		baseURL, url.QueryEscape(c.APIKey), url.QueryEscape(title), year)
+
+	var body searchResponse
+	if err := c.get(endpoint, &body); err != nil {
+		return 0, "", err
+	}
+
+	if len(body.Results) == 0 {
+		return 0, "", fmt.Errorf("tmdb: no match for %q (%d)", title, year)
+	}
+
+	return body.Results[0].ID, body.Results[0].Overview, nil
+}
+
+func (c *Client) details(tmdbID int) (*detailsResponse, error) {
+	endpoint := fmt.Sprintf("%s/movie/%d?api_key=%s", baseURL, tmdbID, url.QueryEscape(c.APIKey))
+
+	var body detailsResponse
+	if err := c.get(endpoint, &body); err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+func (c *Client) directors(tmdbID int) ([]string, error) {
+	endpoint := fmt.Sprintf("%s/movie/%d/credits?api_key=%s", baseURL, tmdbID, url.QueryEscape(c.APIKey))
+
+	var body creditsResponse
+	if err := c.get(endpoint, &body); err != nil {
+		return nil, err
+	}
+
+	var directors []string
+	for _, member := range body.Crew {
+		if member.Job == "Director" {
+			directors = append(directors, member.Name)
+		}
+	}
+
+	return directors, nil
+}
+
+func (c *Client) get(endpoint string, out any) error {
+	resp, err := c.HTTPClient.Get(endpoint)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tmdb: unexpected status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"greenlight.alar.net/internal/clients/imdb"
+	"greenlight.alar.net/internal/clients/tmdb"
+)
+
+// ErrMissingIMDBID is returned by FetchReviews when a movie hasn't been
+// matched to an IMDB title yet (i.e. EnrichFromTMDB hasn't run, or TMDB
+// doesn't know its IMDB id).
+var ErrMissingIMDBID = errors.New("data: movie has no imdb_id")
+
+// EnrichFromTMDB looks the movie up on TMDB and stores the resulting
+// tmdb_id, imdb_id, summary and directors against it. It is invoked by the
+// fetch_tmdb_metadata job, and its imdb_id is what later lets FetchReviews
+// run for the same movie.
+func (m MovieModel) EnrichFromTMDB(ctx context.Context, id int64, client *tmdb.Client) error {
+	movie, err := m.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := client.FindMovie(movie.Title, movie.Year)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		query := `
+			UPDATE movies
+			SET tmdb_id = $1, imdb_id = $2, summary = $3, directors = $4
+			WHERE id = $5`
+
+		_, err := tx.ExecContext(ctx, query, metadata.TMDBID, metadata.IMDBID, metadata.Summary, pq.Array(metadata.Directors), id)
+		return err
+	})
+}
+
+// FetchReviews scrapes the movie's IMDB reviews page (the movie must already
+// have an imdb_id) and stores the results in the reviews table. It is
+// invoked by the fetch_imdb_reviews job.
+func (m MovieModel) FetchReviews(ctx context.Context, id int64, client *imdb.Client) error {
+	movie, err := m.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if movie.IMDBID == "" {
+		return ErrMissingIMDBID
+	}
+
+	reviews, err := client.FetchReviews(movie.IMDBID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		for _, review := range reviews {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO reviews (movie_id, author, body)
+				VALUES ($1, $2, $3)`, id, review.Author, review.Body)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,27 @@
+package data
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ErrDuplicateTitle is returned when an insert or update would violate the
+// movies table's uniqueness constraint on title.
+var ErrDuplicateTitle = errors.New("data: duplicate title")
+
+// uniqueViolationCode is the PostgreSQL error code for a unique constraint
+// violation (23505).
+const uniqueViolationCode = "23505"
+
+// IsUniquenessError reports whether err is a pq.Error caused by a unique
+// constraint violation, analogous to errors.Is checks for sql.ErrNoRows.
+func IsUniquenessError(err error) bool {
+	var pqErr *pq.Error
+
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == uniqueViolationCode
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package data
+
+import "testing"
+
+func TestFiltersSortColumnAndDirection(t *testing.T) {
+	safelist := []string{"id", "title", "year", "-id", "-title", "-year"}
+
+	tests := []struct {
+		sort          string
+		wantColumn    string
+		wantDirection string
+	}{
+		{sort: "id", wantColumn: "id", wantDirection: "ASC"},
+		{sort: "title", wantColumn: "title", wantDirection: "ASC"},
+		{sort: "-year", wantColumn: "year", wantDirection: "DESC"},
+	}
+
+	for _, tt := range tests {
+		f := Filters{Sort: tt.sort, SortSafelist: safelist}
+
+		if got := f.sortColumn(); got != tt.wantColumn {
+			t.Errorf("sortColumn() for %q = %q, want %q", tt.sort, got, tt.wantColumn)
+		}
+
+		if got := f.sortDirection(); got != tt.wantDirection {
+			t.Errorf("sortDirection() for %q = %q, want %q", tt.sort, got, tt.wantDirection)
+		}
+	}
+}
+
+func TestFiltersSortColumnRejectsUnsafeValue(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("sortColumn() did not panic for a value outside the safelist")
+		}
+	}()
+
+	f := Filters{Sort: "title; DROP TABLE movies", SortSafelist: []string{"id", "title"}}
+	f.sortColumn()
+}
+
+func TestCalculateMetadata(t *testing.T) {
+	tests := []struct {
+		name         string
+		totalRecords int
+		page         int
+		pageSize     int
+		want         Metadata
+	}{
+		{
+			name:         "no records",
+			totalRecords: 0,
+			page:         1,
+			pageSize:     20,
+			want:         Metadata{},
+		},
+		{
+			name:         "single page",
+			totalRecords: 5,
+			page:         1,
+			pageSize:     20,
+			want:         Metadata{CurrentPage: 1, PageSize: 20, FirstPage: 1, LastPage: 1, TotalRecords: 5},
+		},
+		{
+			name:         "multiple pages, rounds up",
+			totalRecords: 21,
+			page:         2,
+			pageSize:     20,
+			want:         Metadata{CurrentPage: 2, PageSize: 20, FirstPage: 1, LastPage: 2, TotalRecords: 21},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateMetadata(tt.totalRecords, tt.page, tt.pageSize)
+			if got != tt.want {
+				t.Errorf("calculateMetadata(%d, %d, %d) = %+v, want %+v",
+					tt.totalRecords, tt.page, tt.pageSize, got, tt.want)
+			}
+		})
+	}
+}
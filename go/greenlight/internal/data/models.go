@@ -0,0 +1,34 @@
+package data
+
+import (
+	"database/sql"
+	"time"
+
+	"greenlight.alar.net/internal/jobs"
+)
+
+// defaultQueryTimeout is used by NewModels when no explicit timeout is
+// configured.
+const defaultQueryTimeout = 3 * time.Second
+
+// Models wraps all of our database models together, so that we only need
+// to pass around one thing to our handlers.
+type Models struct {
+	Movies  MovieModel
+	Reviews ReviewModel
+	Jobs    jobs.Model
+}
+
+// NewModels returns a Models struct containing the initialized models. A
+// zero queryTimeout falls back to defaultQueryTimeout.
+func NewModels(db *sql.DB, queryTimeout time.Duration) Models {
+	if queryTimeout == 0 {
+		queryTimeout = defaultQueryTimeout
+	}
+
+	return Models{
+		Movies:  MovieModel{DB: db, QueryTimeout: queryTimeout},
+		Reviews: ReviewModel{DB: db, QueryTimeout: queryTimeout},
+		Jobs:    jobs.Model{DB: db, QueryTimeout: queryTimeout},
+	}
+}
@@ -18,11 +18,41 @@ type Movie struct {
 	// Runtime на самом деле int32, просто мы добавили custom MarshalJSON преобразование
 	Runtime Runtime  `json:"runtime"`
 	Genres  []string `json:"genres"`
-	Version int32    `json:"version"`
+	// Version is a UUID generated by the database on insert and regenerated
+	// on every update; it's surfaced to clients as an ETag and required
+	// back as If-Match for optimistic concurrency control.
+	Version string `json:"version"`
+
+	// Populated by MovieModel.EnrichFromTMDB, invoked from the
+	// fetch_tmdb_metadata job handler; empty until that job has run for
+	// this movie.
+	TMDBID    string   `json:"tmdb_id,omitempty"`
+	IMDBID    string   `json:"imdb_id,omitempty"`
+	Summary   string   `json:"summary,omitempty"`
+	Directors []string `json:"directors,omitempty"`
 }
 
 type MovieModel struct {
-	DB *sql.DB
+	DB           *sql.DB
+	QueryTimeout time.Duration
+}
+
+// withTx runs fn inside a transaction, committing if fn returns nil and
+// rolling back otherwise. It's the basis for any operation that needs to
+// execute more than one statement atomically (e.g. FetchReviews inserting
+// every scraped review for a movie).
+func (m MovieModel) withTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func ValidateMovie(v *validator.Validator, movie *Movie) {
@@ -43,29 +73,48 @@ func ValidateMovie(v *validator.Validator, movie *Movie) {
 
 }
 
-func (m MovieModel) Insert(movie *Movie) error {
+func (m MovieModel) Insert(ctx context.Context, movie *Movie) error {
 	query := `
 		INSERT INTO movies (title, year, runtime, genres)
 		VALUES ($1, $2, $3, $4)
 		RETURNING id, created_at, version
 	`
 	args := []any{movie.Title, movie.Year, movie.Runtime, pq.Array(movie.Genres)}
-	return m.DB.QueryRow(query, args...).Scan(&movie.ID, &movie.CreatedAt, &movie.Version)
+
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	err := m.withTx(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, query, args...).Scan(&movie.ID, &movie.CreatedAt, &movie.Version)
+	})
+	if err != nil {
+		if IsUniquenessError(err) {
+			return ErrDuplicateTitle
+		}
+		return err
+	}
+
+	return nil
 }
 
-func (m MovieModel) Get(id int64) (*Movie, error) {
+func (m MovieModel) Get(ctx context.Context, id int64) (*Movie, error) {
 	if id < 1 {
 		return nil, ErrRecordNotFound
 	}
 
 	query := `
-		SELECT * FROM movies
+		SELECT id, created_at, title, year, runtime, genres, version, tmdb_id, imdb_id, summary, directors
+		FROM movies
 		WHERE id = $1
 	`
 
 	var movie Movie
+	var tmdbID, imdbID, summary sql.NullString
 
-	err := m.DB.QueryRow(query, id).Scan(
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(
 		&movie.ID,
 		&movie.CreatedAt,
 		&movie.Title,
@@ -73,6 +122,10 @@ func (m MovieModel) Get(id int64) (*Movie, error) {
 		&movie.Runtime,
 		pq.Array(&movie.Genres),
 		&movie.Version,
+		&tmdbID,
+		&imdbID,
+		&summary,
+		pq.Array(&movie.Directors),
 	)
 
 	if err != nil {
@@ -84,13 +137,17 @@ func (m MovieModel) Get(id int64) (*Movie, error) {
 		}
 	}
 
+	movie.TMDBID = tmdbID.String
+	movie.IMDBID = imdbID.String
+	movie.Summary = summary.String
+
 	return &movie, nil
 }
 
-func (m MovieModel) Update(movie *Movie) error {
+func (m MovieModel) Update(ctx context.Context, movie *Movie) error {
 	query := `
 		UPDATE movies
-		SET title = $1, year = $2, runtime = $3, genres = $4, version = version + 1
+		SET title = $1, year = $2, runtime = $3, genres = $4, version = uuid_generate_v4()
 		WHERE id = $5 AND version = $6
 		RETURNING version`
 
@@ -103,57 +160,96 @@ func (m MovieModel) Update(movie *Movie) error {
 		movie.Version,          //6
 	}
 
-	return m.DB.QueryRow(query, args...).Scan(&movie.Version)
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	err := m.withTx(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, query, args...).Scan(&movie.Version)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEditConflict
+		case IsUniquenessError(err):
+			return ErrDuplicateTitle
+		default:
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (m MovieModel) Delete(id int64) error {
+// Delete removes the movie, but only if its version still matches the one
+// the caller last read (the same If-Match/412 guarantee Update enforces),
+// so a concurrent writer can't have its update silently discarded by a
+// racing delete.
+func (m MovieModel) Delete(ctx context.Context, id int64, version string) error {
 	if id < 1 {
 		return ErrRecordNotFound
 	}
-	// Construct the SQL query to delete the record.
-	query := `
-		DELETE FROM movies
-		WHERE id = $1`
 
-	result, err := m.DB.Exec(query, id)
-	if err != nil {
-		return err
-	}
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+	return m.withTx(ctx, func(tx *sql.Tx) error {
+		query := `
+			DELETE FROM movies
+			WHERE id = $1 AND version = $2
+			RETURNING id`
 
-	if rowsAffected == 0 {
-		return ErrRecordNotFound
-	}
+		var deletedID int64
+		err := tx.QueryRowContext(ctx, query, id, version).Scan(&deletedID)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
 
-	return nil
+		// Nothing matched both id and version. That's either because the
+		// record is already gone (a concurrent delete raced us) or because
+		// the caller's version is stale, and those need different
+		// responses: a stale caller can resolve a 412 by re-fetching, but
+		// that re-fetch would just 404 if we collapsed both cases to it.
+		var exists bool
+		err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM movies WHERE id = $1)`, id).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrRecordNotFound
+		}
+
+		return ErrEditConflict
+	})
 }
 
-func (m MovieModel) GetAll(title string, genres []string, filters Filters) ([]*Movie, error) {
+func (m MovieModel) GetAll(ctx context.Context, title string, genres []string, filters Filters) ([]*Movie, Metadata, error) {
 	//WHERE (LOWER(title) = LOWER($1) OR $1 = '')
 	//WHERE (STRPOS(LOWER(title), LOWER($1)) > 0 OR $1 = '')
 	//WHERE (title ILIKE $1 OR $1 = '')
 	query := fmt.Sprintf(`
-		SELECT id, created_at, title, year, runtime, genres, version 
+		SELECT count(*) OVER(), id, created_at, title, year, runtime, genres, version
 		FROM movies
 		WHERE (to_tsvector('simple', title) @@ plainto_tsquery('simple', $1) OR $1 = '')
 		AND (genres @> $2 or $2 = '{}')
-		ORDER BY %s %s, id ASC`, filters.sortColumn(), filters.sortDirection())
+		ORDER BY %s %s, id ASC
+		LIMIT $3 OFFSET $4`, filters.sortColumn(), filters.sortDirection())
 
-	// Create a context with a 3-second timeout.
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
 	defer cancel()
 
-	rows, err := m.DB.QueryContext(ctx, query, title, pq.Array(genres))
+	args := []any{title, pq.Array(genres), filters.limit(), filters.offset()}
+
+	rows, err := m.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, Metadata{}, err
 	}
 
 	defer rows.Close()
 
+	totalRecords := 0
 	movies := []*Movie{}
 
 	for rows.Next() {
@@ -162,6 +258,7 @@ func (m MovieModel) GetAll(title string, genres []string, filters Filters) ([]*M
 		// Scan the values from the row into the Movie struct. Again, note that we're using
 		// the pq.Array adapter on the genres field.
 		err := rows.Scan(
+			&totalRecords,
 			&movie.ID,
 			&movie.CreatedAt,
 			&movie.Title,
@@ -171,16 +268,18 @@ func (m MovieModel) GetAll(title string, genres []string, filters Filters) ([]*M
 			&movie.Version,
 		)
 		if err != nil {
-			return nil, err
+			return nil, Metadata{}, err
 		}
 
 		movies = append(movies, &movie)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, err
+		return nil, Metadata{}, err
 	}
 
+	metadata := calculateMetadata(totalRecords, filters.Page, filters.PageSize)
+
 	// If everything went OK, then return the slice of the movies and metadata.
-	return movies, nil
+	return movies, metadata, nil
 }
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type Review struct {
+	ID        int64     `json:"id"`
+	MovieID   int64     `json:"movie_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReviewModel struct {
+	DB           *sql.DB
+	QueryTimeout time.Duration
+}
+
+// GetForMovie returns every scraped review for the given movie, most recent
+// first.
+func (m ReviewModel) GetForMovie(ctx context.Context, movieID int64) ([]*Review, error) {
+	query := `
+		SELECT id, movie_id, author, body, created_at
+		FROM reviews
+		WHERE movie_id = $1
+		ORDER BY created_at DESC`
+
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query, movieID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []*Review{}
+
+	for rows.Next() {
+		var review Review
+
+		err := rows.Scan(&review.ID, &review.MovieID, &review.Author, &review.Body, &review.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, &review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
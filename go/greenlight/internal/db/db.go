@@ -0,0 +1,100 @@
+// Package db owns the application's schema lifecycle: opening the
+// connection pool and applying the embedded goose migrations, so that
+// operators don't need the goose CLI installed alongside the binary.
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pressly/goose/v3"
+
+	"greenlight.alar.net/internal/db/migrations"
+)
+
+type Config struct {
+	DSN          string
+	MaxOpenConns int
+	MaxIdleConns int
+	MaxIdleTime  time.Duration
+}
+
+// EnsureDB opens the connection pool and brings the schema up to date by
+// running every pending migration under internal/db/migrations.
+func EnsureDB(cfg Config) (*sql.DB, error) {
+	database, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrate(database); err != nil {
+		database.Close()
+		return nil, err
+	}
+
+	return database, nil
+}
+
+// Open opens the connection pool without touching the schema.
+func Open(cfg Config) (*sql.DB, error) {
+	database, err := sql.Open("postgres", cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	database.SetMaxOpenConns(cfg.MaxOpenConns)
+	database.SetMaxIdleConns(cfg.MaxIdleConns)
+	database.SetConnMaxIdleTime(cfg.MaxIdleTime)
+
+	if err := database.Ping(); err != nil {
+		database.Close()
+		return nil, err
+	}
+
+	return database, nil
+}
+
+func migrate(database *sql.DB) error {
+	return MigrateUp(database)
+}
+
+// MigrateUp applies every pending migration.
+func MigrateUp(database *sql.DB) error {
+	goose.SetBaseFS(migrations.FS)
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		return err
+	}
+
+	return goose.Up(database, ".")
+}
+
+// MigrateDown rolls back a single migration.
+func MigrateDown(database *sql.DB) error {
+	goose.SetBaseFS(migrations.FS)
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		return err
+	}
+
+	return goose.Down(database, ".")
+}
+
+// MigrateStatus prints the status of every migration to the goose default
+// logger.
+func MigrateStatus(database *sql.DB) error {
+	goose.SetBaseFS(migrations.FS)
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		return err
+	}
+
+	return goose.Status(database, ".")
+}
+
+// MigrateCreate scaffolds a new, empty SQL migration file on disk under
+// internal/db/migrations (embedded FS is read-only, so this targets the
+// real filesystem for the developer to fill in and commit).
+func MigrateCreate(name string) error {
+	return goose.Create(nil, "internal/db/migrations", name, "sql")
+}
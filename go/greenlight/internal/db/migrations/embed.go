@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migration files so the binary can apply
+// its own schema without an external migrate CLI or filesystem access.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,222 @@
+// Package jobs implements a small persistent job queue backed by a
+// PostgreSQL table, used to run long-running enrichment work (fetching
+// metadata and reviews from external sources) outside of the request/
+// response cycle.
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Type identifies the kind of work a job performs. Handlers are registered
+// against a Type in the worker Pool.
+type Type string
+
+const (
+	TypeFetchIMDBReviews  Type = "fetch_imdb_reviews"
+	TypeFetchTMDBMetadata Type = "fetch_tmdb_metadata"
+	TypeRefreshMovie      Type = "refresh_movie"
+)
+
+// Status describes where a job is in its lifecycle.
+type Status string
+
+const (
+	StatusNew     Status = "new"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+var ErrNoJobAvailable = errors.New("jobs: no job available")
+
+// MaxAttempts is the number of times a job is run, the first run included,
+// before it's given up on and left in the failed state.
+const MaxAttempts = 5
+
+type Job struct {
+	ID        int64
+	Type      Type
+	Payload   json.RawMessage
+	Status    Status
+	Attempts  int
+	LastError string
+	NextRunAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Model struct {
+	DB           *sql.DB
+	QueryTimeout time.Duration
+}
+
+// Enqueue inserts a new job in the "new" state, runnable immediately.
+func (m Model) Enqueue(ctx context.Context, jobType Type, payload any) (*Job, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO jobs (type, payload)
+		VALUES ($1, $2)
+		RETURNING id, status, attempts, next_run_at, created_at, updated_at`
+
+	job := &Job{Type: jobType, Payload: body}
+
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	err = m.DB.QueryRowContext(ctx, query, jobType, body).Scan(
+		&job.ID,
+		&job.Status,
+		&job.Attempts,
+		&job.NextRunAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetAll returns every job, most recently created first.
+func (m Model) GetAll(ctx context.Context) ([]*Job, error) {
+	query := `
+		SELECT id, type, payload, status, attempts, coalesce(last_error, ''), next_run_at, created_at, updated_at
+		FROM jobs
+		ORDER BY id DESC`
+
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []*Job{}
+
+	for rows.Next() {
+		var job Job
+
+		err := rows.Scan(
+			&job.ID,
+			&job.Type,
+			&job.Payload,
+			&job.Status,
+			&job.Attempts,
+			&job.LastError,
+			&job.NextRunAt,
+			&job.CreatedAt,
+			&job.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, &job)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// dequeue atomically claims the oldest runnable job whose type is in
+// `types` and marks it as running, so that concurrent workers never pick up
+// the same job.
+func (m Model) dequeue(ctx context.Context, types []Type) (*Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'running', attempts = attempts + 1, updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status = 'new'
+			AND type = ANY($1)
+			AND next_run_at <= NOW()
+			ORDER BY next_run_at ASC
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING id, type, payload, status, attempts, coalesce(last_error, ''), next_run_at, created_at, updated_at`
+
+	job := &Job{}
+
+	typeNames := make([]string, len(types))
+	for i, t := range types {
+		typeNames[i] = string(t)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	err := m.DB.QueryRowContext(ctx, query, pq.Array(typeNames)).Scan(
+		&job.ID,
+		&job.Type,
+		&job.Payload,
+		&job.Status,
+		&job.Attempts,
+		&job.LastError,
+		&job.NextRunAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoJobAvailable
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// markDone marks a job as successfully completed.
+func (m Model) markDone(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, `UPDATE jobs SET status = 'done', updated_at = NOW() WHERE id = $1`, id)
+	return err
+}
+
+// markFailed records the failure. If the job still has attempts remaining it
+// is rescheduled with an exponential backoff, otherwise it's left in the
+// failed state for good.
+func (m Model) markFailed(ctx context.Context, job *Job, cause error) error {
+	ctx, cancel := context.WithTimeout(ctx, m.QueryTimeout)
+	defer cancel()
+
+	if job.Attempts >= MaxAttempts {
+		_, err := m.DB.ExecContext(ctx, `
+			UPDATE jobs SET status = 'failed', last_error = $1, updated_at = NOW()
+			WHERE id = $2`, cause.Error(), job.ID)
+		return err
+	}
+
+	backoff := backoffFor(job.Attempts)
+
+	_, err := m.DB.ExecContext(ctx, `
+		UPDATE jobs SET status = 'new', last_error = $1, next_run_at = NOW() + $2::interval, updated_at = NOW()
+		WHERE id = $3`, cause.Error(), backoff.String(), job.ID)
+	return err
+}
+
+// backoffFor returns the delay to wait before retrying a job that has
+// failed `attempts` times so far, growing exponentially (1s, 2s, 4s, ...).
+func backoffFor(attempts int) time.Duration {
+	return time.Duration(1<<uint(attempts)) * time.Second
+}
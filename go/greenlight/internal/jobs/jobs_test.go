@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffForGrowsExponentially(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: 1 * time.Second},
+		{attempts: 1, want: 2 * time.Second},
+		{attempts: 2, want: 4 * time.Second},
+		{attempts: 3, want: 8 * time.Second},
+		{attempts: 4, want: 16 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := backoffFor(tt.attempts); got != tt.want {
+			t.Errorf("backoffFor(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Handler performs the actual work for a job's payload. It is looked up by
+// the job's Type in the Pool.
+type Handler func(ctx context.Context, payload []byte) error
+
+// Pool polls the jobs table with a fixed number of workers and dispatches
+// each claimed job to its registered Handler.
+type Pool struct {
+	Model    Model
+	Logger   *slog.Logger
+	Handlers map[Type]Handler
+
+	pollInterval time.Duration
+}
+
+func NewPool(model Model, logger *slog.Logger) *Pool {
+	return &Pool{
+		Model:        model,
+		Logger:       logger,
+		Handlers:     make(map[Type]Handler),
+		pollInterval: time.Second,
+	}
+}
+
+// Register associates a Handler with a job Type.
+func (p *Pool) Register(jobType Type, handler Handler) {
+	p.Handlers[jobType] = handler
+}
+
+// Start launches `workers` goroutines that poll for runnable jobs until ctx
+// is cancelled, and blocks until they've all exited.
+func (p *Pool) Start(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+
+	types := make([]Type, 0, len(p.Handlers))
+	for t := range p.Handlers {
+		types = append(types, t)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.run(ctx, types)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (p *Pool) run(ctx context.Context, types []Type) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.processNext(ctx, types)
+		}
+	}
+}
+
+func (p *Pool) processNext(ctx context.Context, types []Type) {
+	job, err := p.Model.dequeue(ctx, types)
+	if err != nil {
+		if !errors.Is(err, ErrNoJobAvailable) {
+			p.Logger.Error("jobs: dequeue failed", "error", err)
+		}
+		return
+	}
+
+	handler, ok := p.Handlers[job.Type]
+	if !ok {
+		p.Logger.Error("jobs: no handler registered", "type", job.Type)
+		_ = p.Model.markFailed(ctx, job, errors.New("no handler registered for job type"))
+		return
+	}
+
+	if err := p.runHandler(ctx, handler, job); err != nil {
+		p.Logger.Error("jobs: handler failed", "type", job.Type, "id", job.ID, "error", err)
+		if err := p.Model.markFailed(ctx, job, err); err != nil {
+			p.Logger.Error("jobs: failed to record failure", "id", job.ID, "error", err)
+		}
+		return
+	}
+
+	if err := p.Model.markDone(ctx, job.ID); err != nil {
+		p.Logger.Error("jobs: failed to record completion", "id", job.ID, "error", err)
+	}
+}
+
+// runHandler invokes handler and recovers from any panic it raises,
+// converting it into an error instead. Handlers call out to third-party
+// scrapers and JSON-decode arbitrary API responses, and a panic in that code
+// must not be allowed to take down the worker goroutine it runs in.
+func (p *Pool) runHandler(ctx context.Context, handler Handler, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("jobs: handler panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job.Payload)
+}